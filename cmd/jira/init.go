@@ -1,15 +1,54 @@
 package jira
 
 import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/mrjones/oauth"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	jiraConfig "github.com/ankitpokhrel/jira-cli/internal/config"
+	"github.com/ankitpokhrel/jira-cli/pkg/jira"
+	"github.com/ankitpokhrel/jira-cli/plugin/ticket"
+	_ "github.com/ankitpokhrel/jira-cli/plugin/ticket/github"
+	_ "github.com/ankitpokhrel/jira-cli/plugin/ticket/gitlab"
 )
 
+// allowedConfigKeys is consulted in --strict mode so a misspelled config
+// key fails fast instead of silently being ignored.
+var allowedConfigKeys = map[string]bool{
+	"server":        true,
+	"login":         true,
+	"api_token":     true,
+	"project":       true,
+	"epic_name":     true,
+	"issue_type":    true,
+	"ticket_system": true,
+	"oauth1":        true,
+	"github":        true,
+	"gitlab":        true,
+	"debug":         true,
+
+	// Read by the jira ticket backend to tune its client middleware.
+	"rate_limit_rps": true,
+	"max_retries":    true,
+}
+
+// defaultTicketSystem is used when comply.yml doesn't set ticket_system,
+// preserving the tool's original Jira-only behaviour.
+const defaultTicketSystem = "jira"
+
+const rsaKeySize = 2048
+
 var initCmd = &cobra.Command{
 	Use:     "init",
 	Short:   "Init initializes jira config",
@@ -18,7 +57,37 @@ var initCmd = &cobra.Command{
 	Run:     initialize,
 }
 
-func initialize(*cobra.Command, []string) {
+func initialize(cmd *cobra.Command, _ []string) {
+	if nonInteractiveInit(cmd) {
+		return
+	}
+
+	system := viper.GetString("ticket_system")
+	if system == "" {
+		system = defaultTicketSystem
+	}
+
+	if system != defaultTicketSystem {
+		setup, ok := ticket.Setup(system)
+		if !ok {
+			fmt.Printf("\n\033[0;31m✗\033[0m Unknown ticket_system: %s\n", system)
+			os.Exit(1)
+		}
+
+		if err := setup(); err != nil {
+			fmt.Printf("\n\033[0;31m✗\033[0m Unable to generate configuration: %s\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\n\033[0;32m✓\033[0m Configuration generated: %s\n", viper.ConfigFileUsed())
+		return
+	}
+
+	if useOAuth() {
+		initOAuth()
+		return
+	}
+
 	c := jiraConfig.NewJiraCLIConfig()
 
 	if err := c.Generate(); err != nil {
@@ -34,6 +103,223 @@ func initialize(*cobra.Command, []string) {
 	fmt.Printf("\n\033[0;32m✓\033[0m Configuration generated: %s\n", viper.ConfigFileUsed())
 }
 
+// nonInteractiveInit provisions the config directly from flags/env vars,
+// skipping every prompt, so `init` can run from CI or infrastructure as
+// code. It reports whether it handled the init (i.e. at least one of the
+// required values was supplied).
+func nonInteractiveInit(cmd *cobra.Command) bool {
+	server := flagOrEnv(cmd, "server", "JIRA_SERVER")
+	login := flagOrEnv(cmd, "login", "JIRA_LOGIN")
+	token := flagOrEnv(cmd, "api-token", "JIRA_API_TOKEN")
+	project := flagOrEnv(cmd, "project", "JIRA_PROJECT")
+
+	if server == "" && login == "" && token == "" && project == "" {
+		return false
+	}
+	if server == "" || login == "" || token == "" || project == "" {
+		fmt.Println("\n\033[0;31m✗\033[0m --server, --login, --api-token and --project (or their JIRA_* env vars) are all required for non-interactive init")
+		os.Exit(1)
+	}
+
+	epicName := flagOrEnv(cmd, "epic-name", "JIRA_EPIC_NAME")
+	issueType := flagOrEnv(cmd, "issue-type", "JIRA_ISSUE_TYPE")
+	cfgPath, _ := cmd.Flags().GetString("config")
+	force, _ := cmd.Flags().GetBool("force")
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	target := cfgPath
+	if target == "" {
+		target = viper.ConfigFileUsed()
+	}
+	if !force && target != "" {
+		if _, err := os.Stat(target); err == nil {
+			fmt.Printf("\n\033[0;31m✗\033[0m Config already exists at %s, pass --force to overwrite\n", target)
+			os.Exit(1)
+		}
+	}
+
+	if strict {
+		client := jira.NewClient(jira.Config{Server: server, Login: login, APIToken: token})
+		if err := client.Do(context.Background(), http.MethodGet, "/myself", nil, &struct{}{}); err != nil {
+			fmt.Printf("\n\033[0;31m✗\033[0m Unable to validate credentials: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	viper.Set("server", server)
+	viper.Set("login", login)
+	viper.Set("api_token", token)
+	viper.Set("project", project)
+	if epicName != "" {
+		viper.Set("epic_name", epicName)
+	}
+	if issueType != "" {
+		viper.Set("issue_type", issueType)
+	}
+
+	// Validated after the values above are set, so --strict actually
+	// inspects the config this run is about to write instead of whatever
+	// (usually nothing) viper had loaded beforehand.
+	if strict {
+		if err := validateConfigKeys(); err != nil {
+			fmt.Printf("\n\033[0;31m✗\033[0m %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var err error
+	if cfgPath != "" {
+		err = viper.WriteConfigAs(cfgPath)
+	} else {
+		err = viper.WriteConfig()
+	}
+	if err != nil {
+		fmt.Printf("\n\033[0;31m✗\033[0m Unable to write configuration: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n\033[0;32m✓\033[0m Configuration generated: %s\n", viper.ConfigFileUsed())
+
+	return true
+}
+
+// flagOrEnv returns the flag's value if set, otherwise falls back to the
+// given environment variable.
+func flagOrEnv(cmd *cobra.Command, flag, env string) string {
+	if v, _ := cmd.Flags().GetString(flag); v != "" {
+		return v
+	}
+
+	return os.Getenv(env)
+}
+
+// validateConfigKeys rejects unknown/misspelled top-level config keys
+// instead of letting viper silently ignore them.
+func validateConfigKeys() error {
+	for key := range viper.AllSettings() {
+		if !allowedConfigKeys[key] {
+			return fmt.Errorf("unknown config key: %q", key)
+		}
+	}
+
+	return nil
+}
+
+// useOAuth asks the user which authentication mode they want to configure.
+// Self-hosted Jira instances that don't issue API tokens need OAuth 1.0a
+// instead of the default login/APIToken basic auth.
+func useOAuth() bool {
+	fmt.Print("Use OAuth 1.0a authentication instead of an API token? (y/N): ")
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	return strings.EqualFold(strings.TrimSpace(answer), "y")
+}
+
+// oauth1ServiceProvider builds the Application Links OAuth endpoints Jira
+// exposes at a fixed path under the server's base URL.
+func oauth1ServiceProvider(server string) oauth.ServiceProvider {
+	return oauth.ServiceProvider{
+		RequestTokenUrl:   server + "/plugins/servlet/oauth/request-token",
+		AuthorizeTokenUrl: server + "/plugins/servlet/oauth/authorize",
+		AccessTokenUrl:    server + "/plugins/servlet/oauth/access-token",
+		HttpMethod:        http.MethodPost,
+	}
+}
+
+// initOAuth walks the user through generating an RSA keypair and driving
+// Jira's Application Links three-legged OAuth 1.0a dance: request a token,
+// send the user to the authorize URL, then exchange the verification code
+// it prints for a permanent access token. The resulting credentials are
+// persisted so the jira backend can sign requests with them.
+func initOAuth() {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println("\nGenerating RSA keypair for OAuth 1.0a...")
+
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		fmt.Printf("\n\033[0;31m✗\033[0m Unable to generate RSA keypair: %s\n", err)
+		os.Exit(1)
+	}
+
+	pubPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: mustMarshalPKIXPublicKey(&key.PublicKey),
+	})
+
+	fmt.Print("\nAdd the following public key to a new Application Link in Jira (Administration > Application Links):\n\n")
+	fmt.Print(string(pubPEM))
+
+	fmt.Print("\nConsumer key configured on the Application Link: ")
+	consumerKey, _ := reader.ReadString('\n')
+	consumerKey = strings.TrimSpace(consumerKey)
+
+	fmt.Print("Server URL: ")
+	server, _ := reader.ReadString('\n')
+	server = strings.TrimSpace(server)
+
+	consumer := oauth.NewRSAConsumer(consumerKey, key, oauth1ServiceProvider(server))
+
+	requestToken, authorizeURL, err := consumer.GetRequestTokenAndUrl("oob")
+	if err != nil {
+		fmt.Printf("\n\033[0;31m✗\033[0m Unable to get a request token: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nOpen the following URL in a browser and authorize the request:\n\n%s\n\n", authorizeURL)
+	fmt.Print("Verification code shown after authorizing: ")
+	verificationCode, _ := reader.ReadString('\n')
+	verificationCode = strings.TrimSpace(verificationCode)
+
+	accessToken, err := consumer.AuthorizeToken(requestToken, verificationCode)
+	if err != nil {
+		fmt.Printf("\n\033[0;31m✗\033[0m Unable to exchange the request token for an access token: %s\n", err)
+		os.Exit(1)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	viper.Set("server", server)
+	viper.Set("oauth1.consumer_key", consumerKey)
+	viper.Set("oauth1.private_key", string(keyPEM))
+	viper.Set("oauth1.access_token", accessToken.Token)
+	viper.Set("oauth1.access_secret", accessToken.Secret)
+
+	if err := viper.WriteConfig(); err != nil {
+		fmt.Printf("\n\033[0;31m✗\033[0m Unable to generate configuration: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n\033[0;32m✓\033[0m Configuration generated: %s\n", viper.ConfigFileUsed())
+}
+
+// mustMarshalPKIXPublicKey encodes pub in the PKIX (SubjectPublicKeyInfo)
+// form expected under a "PUBLIC KEY" PEM header — the format Jira's
+// Application Links page (and `openssl rsa -pubout`) expects. Encoding
+// never fails for an *rsa.PublicKey.
+func mustMarshalPKIXPublicKey(pub *rsa.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		panic(err)
+	}
+
+	return der
+}
+
 func init() {
+	initCmd.Flags().String("server", "", "Jira server URL (env: JIRA_SERVER)")
+	initCmd.Flags().String("login", "", "Jira login email (env: JIRA_LOGIN)")
+	initCmd.Flags().String("api-token", "", "Jira API token (env: JIRA_API_TOKEN)")
+	initCmd.Flags().String("project", "", "Jira project key (env: JIRA_PROJECT)")
+	initCmd.Flags().String("epic-name", "", "Jira epic name custom field (env: JIRA_EPIC_NAME)")
+	initCmd.Flags().String("issue-type", "", "Default issue type (env: JIRA_ISSUE_TYPE)")
+	initCmd.Flags().Bool("force", false, "Overwrite an existing config file without prompting")
+	initCmd.Flags().String("config", "", "Path to write the config file to")
+	initCmd.Flags().Bool("strict", false, "Validate credentials against Jira and reject unknown config keys before persisting")
+
 	rootCmd.AddCommand(initCmd)
 }
@@ -0,0 +1,73 @@
+package jira
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newTestInitCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("server", "", "")
+	cmd.Flags().String("login", "", "")
+	cmd.Flags().String("api-token", "", "")
+	cmd.Flags().String("project", "", "")
+	cmd.Flags().String("epic-name", "", "")
+	cmd.Flags().String("issue-type", "", "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().Bool("strict", false, "")
+
+	return cmd
+}
+
+func TestFlagOrEnv(t *testing.T) {
+	cmd := newTestInitCmd()
+
+	if got := flagOrEnv(cmd, "server", "JIRA_SERVER_TEST"); got != "" {
+		t.Fatalf("flagOrEnv() = %q, want empty", got)
+	}
+
+	t.Setenv("JIRA_SERVER_TEST", "https://env.atlassian.net")
+	if got := flagOrEnv(cmd, "server", "JIRA_SERVER_TEST"); got != "https://env.atlassian.net" {
+		t.Fatalf("flagOrEnv() = %q, want env fallback", got)
+	}
+
+	if err := cmd.Flags().Set("server", "https://flag.atlassian.net"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got := flagOrEnv(cmd, "server", "JIRA_SERVER_TEST"); got != "https://flag.atlassian.net" {
+		t.Fatalf("flagOrEnv() = %q, want flag value to take precedence over env", got)
+	}
+}
+
+func TestValidateConfigKeys(t *testing.T) {
+	viper.Set("server", "https://example.atlassian.net")
+	viper.Set("project", "ABC")
+
+	if err := validateConfigKeys(); err != nil {
+		t.Fatalf("validateConfigKeys() error = %v, want nil for known keys", err)
+	}
+
+	viper.Set("srever", "typo")
+
+	if err := validateConfigKeys(); err == nil {
+		t.Fatal("validateConfigKeys() = nil, want error for unknown key")
+	}
+}
+
+func TestNonInteractiveInitReturnsFalseWhenNothingConfigured(t *testing.T) {
+	cmd := newTestInitCmd()
+
+	for _, env := range []string{"JIRA_SERVER", "JIRA_LOGIN", "JIRA_API_TOKEN", "JIRA_PROJECT"} {
+		if err := os.Unsetenv(env); err != nil {
+			t.Fatalf("Unsetenv(%s) error = %v", env, err)
+		}
+	}
+
+	if nonInteractiveInit(cmd) {
+		t.Fatal("nonInteractiveInit() = true, want false when nothing is configured")
+	}
+}
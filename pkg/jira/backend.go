@@ -0,0 +1,256 @@
+package jira
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/viper"
+
+	"github.com/ankitpokhrel/jira-cli/plugin/ticket"
+)
+
+// Defaults applied to the client the jira backend builds for production
+// use, so Jira Cloud's rate limiting and transient failures don't take
+// down the ticketing workflow without every caller having to configure
+// middleware by hand. The rate and the retry count are overridable via the
+// rate_limit_rps and max_retries cfg keys; the burst size is fixed.
+const (
+	defaultRateLimitRPS   = 10
+	defaultRateLimitBurst = 20
+	defaultMaxRetries     = 3
+)
+
+func init() {
+	ticket.Register("jira", newBackend)
+}
+
+// backend adapts Client to the ticket.Backend interface so comply can drive
+// Jira through the pluggable ticketing abstraction alongside other
+// trackers.
+type backend struct {
+	client    *Client
+	project   string
+	issueType string
+}
+
+func newBackend(cfg map[string]interface{}) (ticket.Backend, error) {
+	server, _ := cfg["server"].(string)
+	login, _ := cfg["login"].(string)
+	token, _ := cfg["api_token"].(string)
+	project, _ := cfg["project"].(string)
+	issueType, _ := cfg["issue_type"].(string)
+
+	if server == "" || project == "" {
+		return nil, fmt.Errorf("jira: server and project are required")
+	}
+
+	clientCfg := Config{Server: server, Login: login, APIToken: token}
+	if oauth1 := oauth1ConfigFromViper(); oauth1 != nil {
+		clientCfg.OAuth1 = oauth1
+	}
+
+	client := NewClient(clientCfg,
+		WithRateLimit(floatConfigOrDefault(cfg, "rate_limit_rps", defaultRateLimitRPS), defaultRateLimitBurst),
+		WithMaxRetries(intConfigOrDefault(cfg, "max_retries", defaultMaxRetries)),
+		WithResponseCache(),
+	)
+
+	return &backend{client: client, project: project, issueType: issueType}, nil
+}
+
+func floatConfigOrDefault(cfg map[string]interface{}, key string, def float64) float64 {
+	switch v := cfg[key].(type) {
+	case float64:
+		if v > 0 {
+			return v
+		}
+	case int:
+		// comply.yml values like `rate_limit_rps: 10` decode as int, not
+		// float64, since they contain no decimal point.
+		if v > 0 {
+			return float64(v)
+		}
+	}
+
+	return def
+}
+
+func intConfigOrDefault(cfg map[string]interface{}, key string, def int) int {
+	if v, ok := cfg[key].(int); ok && v > 0 {
+		return v
+	}
+
+	return def
+}
+
+// oauth1ConfigFromViper builds an OAuth1Config from the persisted config so
+// the production client is built with the OAuth 1.0a credentials `jira
+// init`'s interactive flow collected, when present.
+func oauth1ConfigFromViper() *OAuth1Config {
+	if viper.GetString("oauth1.consumer_key") == "" {
+		return nil
+	}
+
+	key, err := parseRSAPrivateKey(viper.GetString("oauth1.private_key"))
+	if err != nil {
+		return nil
+	}
+
+	return &OAuth1Config{
+		ConsumerKey:  viper.GetString("oauth1.consumer_key"),
+		PrivateKey:   key,
+		AccessToken:  viper.GetString("oauth1.access_token"),
+		AccessSecret: viper.GetString("oauth1.access_secret"),
+	}
+}
+
+func parseRSAPrivateKey(keyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("jira: invalid PEM block for oauth1 private key")
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+type issueFields struct {
+	Project     struct{ Key string }  `json:"project"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	IssueType   struct{ Name string } `json:"issuetype"`
+}
+
+type issueRequest struct {
+	Fields issueFields `json:"fields"`
+}
+
+type issueResponse struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+func (b *backend) CreateTicket(ctx context.Context, t ticket.Ticket) (*ticket.Ticket, error) {
+	req := issueRequest{}
+	req.Fields.Project.Key = b.project
+	req.Fields.Summary = t.Title
+	req.Fields.Description = t.Body
+	req.Fields.IssueType.Name = b.issueType
+
+	var res issueResponse
+	if err := b.client.DoV2(ctx, http.MethodPost, "/issue", req, &res); err != nil {
+		return nil, err
+	}
+
+	return &ticket.Ticket{ID: res.ID, Key: res.Key, Title: t.Title, Body: t.Body, URL: res.Self}, nil
+}
+
+func (b *backend) GetTicket(ctx context.Context, id string) (*ticket.Ticket, error) {
+	var res struct {
+		ID     string `json:"id"`
+		Key    string `json:"key"`
+		Fields struct {
+			Summary     string `json:"summary"`
+			Description string `json:"description"`
+			Status      struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+
+	if err := b.client.DoV2(ctx, http.MethodGet, "/issue/"+id, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return &ticket.Ticket{
+		ID:     res.ID,
+		Key:    res.Key,
+		Title:  res.Fields.Summary,
+		Body:   res.Fields.Description,
+		Status: res.Fields.Status.Name,
+	}, nil
+}
+
+func (b *backend) ListTickets(ctx context.Context, query string) ([]ticket.Ticket, error) {
+	return b.Search(ctx, query)
+}
+
+func (b *backend) Search(ctx context.Context, jql string) ([]ticket.Ticket, error) {
+	var res struct {
+		Issues []struct {
+			ID     string `json:"id"`
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string `json:"summary"`
+				Status  struct {
+					Name string `json:"name"`
+				} `json:"status"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+
+	if err := b.client.DoV2(ctx, http.MethodPost, "/search", map[string]string{"jql": jql}, &res); err != nil {
+		return nil, err
+	}
+
+	tickets := make([]ticket.Ticket, 0, len(res.Issues))
+	for _, issue := range res.Issues {
+		tickets = append(tickets, ticket.Ticket{
+			ID:     issue.ID,
+			Key:    issue.Key,
+			Title:  issue.Fields.Summary,
+			Status: issue.Fields.Status.Name,
+		})
+	}
+
+	return tickets, nil
+}
+
+func (b *backend) TransitionTicket(ctx context.Context, id, status string) error {
+	transitions, err := b.transitions(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	transitionID, ok := transitions[status]
+	if !ok {
+		return fmt.Errorf("jira: no transition to status %q for issue %s", status, id)
+	}
+
+	body := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+
+	return b.client.DoV2(ctx, http.MethodPost, "/issue/"+id+"/transitions", body, nil)
+}
+
+func (b *backend) transitions(ctx context.Context, id string) (map[string]string, error) {
+	var res struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+
+	if err := b.client.DoV2(ctx, http.MethodGet, "/issue/"+id+"/transitions", nil, &res); err != nil {
+		return nil, err
+	}
+
+	byStatus := make(map[string]string, len(res.Transitions))
+	for _, t := range res.Transitions {
+		byStatus[t.To.Name] = t.ID
+	}
+
+	return byStatus, nil
+}
+
+func (b *backend) AddComment(ctx context.Context, id string, c ticket.Comment) error {
+	return b.client.DoV2(ctx, http.MethodPost, "/issue/"+id+"/comment", map[string]string{"body": c.Body}, nil)
+}
@@ -1,12 +1,23 @@
 package jira
 
 import (
+	"bytes"
 	"context"
+	"crypto/rsa"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math/rand"
 	"net"
 	"net/http"
+	"net/http/cookiejar"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/mrjones/oauth"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -14,6 +25,7 @@ const (
 	RFC3339 = "2006-01-02T15:04:05-0700"
 
 	baseURLv3 = "/rest/api/3"
+	baseURLv2 = "/rest/api/2"
 	baseURLv1 = "/rest/agile/1.0"
 )
 
@@ -26,17 +38,105 @@ var (
 	ErrUnexpectedStatusCode = fmt.Errorf("jira: unexpected status code")
 )
 
+// APIError is a structured error parsed out of a non-2xx Jira response. It
+// carries the HTTP status alongside whatever error detail Jira included in
+// its standard error envelope.
+type APIError struct {
+	StatusCode int
+	Messages   []string
+	Errors     map[string]string
+}
+
+func (e *APIError) Error() string {
+	if len(e.Messages) == 0 && len(e.Errors) == 0 {
+		return fmt.Sprintf("jira: unexpected status code: %d", e.StatusCode)
+	}
+
+	parts := make([]string, 0, len(e.Messages)+len(e.Errors))
+	parts = append(parts, e.Messages...)
+	for field, msg := range e.Errors {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, msg))
+	}
+
+	return fmt.Sprintf("jira: %d: %s", e.StatusCode, strings.Join(parts, "; "))
+}
+
+// errorEnvelope mirrors the shape Jira uses to report errors on non-2xx
+// responses across both the v3 and agile APIs.
+type errorEnvelope struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// OAuth1Config holds the credentials needed to sign requests using OAuth
+// 1.0a, as required by Jira's Application Links flow. The private key is
+// the one used to sign the request-token exchange when the application
+// link was set up on the Jira side.
+type OAuth1Config struct {
+	ConsumerKey  string
+	PrivateKey   *rsa.PrivateKey
+	AccessToken  string
+	AccessSecret string
+}
+
 // Config is a jira config.
 type Config struct {
 	Server   string
 	Login    string
 	APIToken string
+	OAuth1   *OAuth1Config
 	Debug    bool
 }
 
+// signer authenticates an outgoing request and performs the round trip.
+// mrjones/oauth signs and sends in one step (it needs to sign over the
+// final, fully-assembled request), so authentication and transport are
+// combined here rather than split into a header-mutation step followed by
+// a separate RoundTrip.
+type signer interface {
+	Send(req *http.Request) (*http.Response, error)
+}
+
+// basicSigner sends requests using HTTP Basic auth.
+type basicSigner struct {
+	login     string
+	token     string
+	transport http.RoundTripper
+}
+
+func (s *basicSigner) Send(req *http.Request) (*http.Response, error) {
+	req.SetBasicAuth(s.login, s.token)
+	return s.transport.RoundTrip(req)
+}
+
+// oauth1Signer sends requests signed with OAuth 1.0a RSA-SHA1, per Jira's
+// Application Links flow.
+type oauth1Signer struct {
+	roundTripper *oauth.RoundTripper
+}
+
+func newOAuth1Signer(cfg OAuth1Config, transport http.RoundTripper) *oauth1Signer {
+	consumer := oauth.NewRSAConsumer(cfg.ConsumerKey, cfg.PrivateKey, oauth.ServiceProvider{})
+	consumer.HttpClient = &http.Client{Transport: transport}
+
+	roundTripper, _ := consumer.MakeRoundTripper(&oauth.AccessToken{
+		Token:  cfg.AccessToken,
+		Secret: cfg.AccessSecret,
+	})
+
+	return &oauth1Signer{roundTripper: roundTripper}
+}
+
+func (s *oauth1Signer) Send(req *http.Request) (*http.Response, error) {
+	return s.roundTripper.RoundTrip(req)
+}
+
 // Client is a jira client.
 type Client struct {
 	transport http.RoundTripper
+	jar       http.CookieJar
+	signer    signer
+	oauth1    *OAuth1Config
 	server    string
 	login     string
 	token     string
@@ -53,6 +153,7 @@ func NewClient(c Config, opts ...ClientFunc) *Client {
 		server: strings.TrimSuffix(c.Server, "/"),
 		login:  c.Login,
 		token:  c.APIToken,
+		oauth1: c.OAuth1,
 		debug:  c.Debug,
 	}
 
@@ -63,10 +164,22 @@ func NewClient(c Config, opts ...ClientFunc) *Client {
 		}).DialContext,
 	}
 
+	client.jar, _ = cookiejar.New(nil)
+
 	for _, opt := range opts {
 		opt(&client)
 	}
 
+	// The signer is built last, once every opt has had a chance to wrap
+	// client.transport (WithRateLimit/WithMaxRetries/WithResponseCache),
+	// so OAuth1-signed requests still flow through that middleware chain
+	// instead of bypassing it.
+	if client.oauth1 != nil {
+		client.signer = newOAuth1Signer(*client.oauth1, client.transport)
+	} else {
+		client.signer = &basicSigner{login: client.login, token: client.token, transport: client.transport}
+	}
+
 	return &client
 }
 
@@ -77,29 +190,363 @@ func WithTimeout(to time.Duration) ClientFunc {
 	}
 }
 
+// WithOAuth1 is a functional opt that switches the client to sign requests
+// using OAuth 1.0a instead of HTTP Basic auth.
+func WithOAuth1(cfg OAuth1Config) ClientFunc {
+	return func(c *Client) {
+		c.oauth1 = &cfg
+	}
+}
+
+// WithRateLimit throttles outgoing requests to at most rps requests per
+// second, allowing short bursts up to burst. Useful for staying under
+// Jira Cloud's rate limits before it starts responding with 429s.
+func WithRateLimit(rps float64, burst int) ClientFunc {
+	return func(c *Client) {
+		c.transport = &rateLimitedTransport{
+			next:    c.transport,
+			limiter: rate.NewLimiter(rate.Limit(rps), burst),
+		}
+	}
+}
+
+// WithMaxRetries retries requests that fail with a 429/502/503/504 or a
+// network error, honoring any Retry-After header and otherwise backing off
+// exponentially with jitter. Retries stop early if the request context is
+// canceled.
+func WithMaxRetries(n int) ClientFunc {
+	return func(c *Client) {
+		c.transport = &retryTransport{next: c.transport, maxRetries: n}
+	}
+}
+
+// WithResponseCache enables an ETag-aware cache for GET requests. Cached
+// entries are revalidated with a conditional request and replayed locally
+// when Jira responds with 304 Not Modified.
+func WithResponseCache() ClientFunc {
+	return func(c *Client) {
+		c.transport = newCacheTransport(c.transport)
+	}
+}
+
+// rateLimitedTransport throttles requests through a token-bucket limiter
+// before handing them to the next transport in the chain.
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// retryTransport retries requests that fail transiently, honoring
+// Retry-After and applying exponential backoff with jitter otherwise.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	var res *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		res, err = t.next.RoundTrip(req)
+		if !shouldRetry(res, err) || attempt == t.maxRetries {
+			return res, err
+		}
+
+		wait := retryDelay(res, attempt)
+		if res != nil {
+			_ = res.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryDelay(res *http.Response, attempt int) time.Duration {
+	if res != nil {
+		if ra := res.Header.Get("Retry-After"); ra != "" {
+			if secs, err := strconv.Atoi(ra); err == nil {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+
+	base := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+
+	return base + time.Duration(rand.Int63n(int64(base)))
+}
+
+// cacheEntry holds a cached GET response along with the validators needed
+// to revalidate it on the next request.
+type cacheEntry struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+// cacheTransport implements an ETag-aware cache for GET requests, keyed by
+// URL.
+type cacheTransport struct {
+	next  http.RoundTripper
+	mu    sync.Mutex
+	store map[string]*cacheEntry
+}
+
+func newCacheTransport(next http.RoundTripper) *cacheTransport {
+	return &cacheTransport{next: next, store: make(map[string]*cacheEntry)}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+
+	t.mu.Lock()
+	entry, cached := t.store[key]
+	t.mu.Unlock()
+
+	if cached {
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached && res.StatusCode == http.StatusNotModified {
+		_ = res.Body.Close()
+		return entry.toResponse(req), nil
+	}
+
+	if res.StatusCode == http.StatusOK {
+		if etag := res.Header.Get("ETag"); etag != "" {
+			body, err := io.ReadAll(res.Body)
+			_ = res.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+
+			res.Body = io.NopCloser(bytes.NewReader(body))
+
+			t.mu.Lock()
+			t.store[key] = &cacheEntry{
+				etag:         etag,
+				lastModified: res.Header.Get("Last-Modified"),
+				header:       res.Header.Clone(),
+				body:         body,
+			}
+			t.mu.Unlock()
+		}
+	}
+
+	return res, nil
+}
+
+func (e *cacheEntry) toResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
 // Get sends get request to v3 version of the jira api.
 func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
-	return c.request(ctx, c.server+baseURLv3+path)
+	return c.request(ctx, http.MethodGet, c.server+baseURLv3+path, nil)
 }
 
 // GetV1 sends get request to v1 version of the jira api.
 func (c *Client) GetV1(ctx context.Context, path string) (*http.Response, error) {
-	return c.request(ctx, c.server+baseURLv1+path)
+	return c.request(ctx, http.MethodGet, c.server+baseURLv1+path, nil)
+}
+
+// Post sends a post request with a JSON encoded body to the v3 version of
+// the jira api and decodes the response into out.
+func (c *Client) Post(ctx context.Context, path string, body, out interface{}) error {
+	return c.Do(ctx, http.MethodPost, path, body, out)
+}
+
+// PostV3 is an alias of Post kept for symmetry with PostV1.
+func (c *Client) PostV3(ctx context.Context, path string, body, out interface{}) error {
+	return c.Post(ctx, path, body, out)
+}
+
+// PostV1 sends a post request with a JSON encoded body to the v1 version of
+// the jira api and decodes the response into out.
+func (c *Client) PostV1(ctx context.Context, path string, body, out interface{}) error {
+	return c.doVersioned(ctx, http.MethodPost, baseURLv1, path, body, out)
+}
+
+// Put sends a put request with a JSON encoded body to the v3 version of the
+// jira api and decodes the response into out.
+func (c *Client) Put(ctx context.Context, path string, body, out interface{}) error {
+	return c.Do(ctx, http.MethodPut, path, body, out)
 }
 
-func (c *Client) request(ctx context.Context, endpoint string) (*http.Response, error) {
+// PutV1 sends a put request with a JSON encoded body to the v1 version of
+// the jira api and decodes the response into out.
+func (c *Client) PutV1(ctx context.Context, path string, body, out interface{}) error {
+	return c.doVersioned(ctx, http.MethodPut, baseURLv1, path, body, out)
+}
+
+// Delete sends a delete request to the v3 version of the jira api and
+// decodes the response into out, if given.
+func (c *Client) Delete(ctx context.Context, path string, out interface{}) error {
+	return c.Do(ctx, http.MethodDelete, path, nil, out)
+}
+
+// DeleteV1 sends a delete request to the v1 version of the jira api and
+// decodes the response into out, if given.
+func (c *Client) DeleteV1(ctx context.Context, path string, out interface{}) error {
+	return c.doVersioned(ctx, http.MethodDelete, baseURLv1, path, nil, out)
+}
+
+// Do sends a request with an optional JSON encoded body to the v3 version
+// of the jira api and decodes a successful response into out. A non-2xx
+// response is translated into an *APIError.
+func (c *Client) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.doVersioned(ctx, method, baseURLv3, path, body, out)
+}
+
+// DoV2 is the v2-api equivalent of Do. Unlike v3, the v2 issue/comment
+// endpoints accept plain-text description/body fields instead of requiring
+// Atlassian Document Format, which is what makes it the right choice for
+// callers that only deal in plain strings.
+func (c *Client) DoV2(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.doVersioned(ctx, method, baseURLv2, path, body, out)
+}
+
+func (c *Client) doVersioned(ctx context.Context, method, baseURL, path string, body, out interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	res, err := c.request(ctx, method, c.server+baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return newAPIError(res)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// RawRequest is an escape hatch for endpoints that need direct access to
+// the response body, such as streaming or attachment downloads/uploads. It
+// signs and dispatches the request the same way as the typed helpers but
+// leaves encoding/decoding of body and response to the caller.
+func (c *Client) RawRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	return c.request(ctx, method, endpoint, body)
+}
+
+func newAPIError(res *http.Response) error {
+	apiErr := &APIError{StatusCode: res.StatusCode}
+
+	var envelope errorEnvelope
+	if err := json.NewDecoder(res.Body).Decode(&envelope); err == nil {
+		apiErr.Messages = envelope.ErrorMessages
+		apiErr.Errors = envelope.Errors
+	}
+
+	return apiErr
+}
+
+func (c *Client) request(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
 	if c.debug {
-		fmt.Printf("Requesting: %s\n", endpoint)
+		fmt.Printf("%s: %s\n", method, endpoint)
 	}
 
-	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	req, err := http.NewRequest(method, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	req.SetBasicAuth(c.login, c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-Atlassian-Token", "nocheck")
 
-	res, err := c.transport.RoundTrip(req.WithContext(ctx))
+	if c.jar != nil {
+		for _, cookie := range c.jar.Cookies(req.URL) {
+			req.AddCookie(cookie)
+		}
+	}
+
+	res, err := c.signer.Send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.jar != nil && res != nil {
+		c.jar.SetCookies(req.URL, res.Cookies())
+	}
 
-	return res, err
+	return res, nil
 }
@@ -0,0 +1,206 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubRoundTripper struct {
+	fn func(*http.Request) (*http.Response, error)
+}
+
+func (s *stubRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return s.fn(req)
+}
+
+func TestShouldRetry(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{
+		{"network error", 0, errors.New("boom"), true},
+		{"too many requests", http.StatusTooManyRequests, nil, true},
+		{"bad gateway", http.StatusBadGateway, nil, true},
+		{"service unavailable", http.StatusServiceUnavailable, nil, true},
+		{"gateway timeout", http.StatusGatewayTimeout, nil, true},
+		{"ok", http.StatusOK, nil, false},
+		{"not found", http.StatusNotFound, nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var res *http.Response
+			if tc.err == nil {
+				res = &http.Response{StatusCode: tc.status}
+			}
+
+			if got := shouldRetry(res, tc.err); got != tc.want {
+				t.Fatalf("shouldRetry() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayHonorsRetryAfter(t *testing.T) {
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+
+	if got := retryDelay(res, 5); got != 2*time.Second {
+		t.Fatalf("retryDelay() = %v, want 2s", got)
+	}
+}
+
+func TestRetryDelayBacksOffExponentially(t *testing.T) {
+	base0 := 200 * time.Millisecond
+	base3 := time.Duration(1<<3) * 200 * time.Millisecond
+
+	if d0 := retryDelay(nil, 0); d0 < base0 || d0 >= 2*base0 {
+		t.Fatalf("retryDelay(nil, 0) = %v, want in [%v, %v)", d0, base0, 2*base0)
+	}
+	if d3 := retryDelay(nil, 3); d3 < base3 || d3 >= 2*base3 {
+		t.Fatalf("retryDelay(nil, 3) = %v, want in [%v, %v)", d3, base3, 2*base3)
+	}
+}
+
+func TestRetryTransportRetriesThenSucceeds(t *testing.T) {
+	attempts := 0
+	next := &stubRoundTripper{fn: func(*http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	}}
+
+	rt := &retryTransport{next: next, maxRetries: 5}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com", nil)
+
+	res, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, want 200", res.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetryTransportRespectsContextCancellation(t *testing.T) {
+	next := &stubRoundTripper{fn: func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}}
+
+	rt := &retryTransport{next: next, maxRetries: 5}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RoundTrip() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCacheTransportServesFromCacheOn304(t *testing.T) {
+	calls := 0
+	next := &stubRoundTripper{fn: func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			h := http.Header{}
+			h.Set("ETag", `"abc"`)
+			return &http.Response{StatusCode: http.StatusOK, Header: h, Body: io.NopCloser(strings.NewReader("payload"))}, nil
+		}
+
+		if req.Header.Get("If-None-Match") != `"abc"` {
+			t.Fatalf("revalidation request missing If-None-Match")
+		}
+		return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}}
+
+	ct := newCacheTransport(next)
+
+	req1, _ := http.NewRequest(http.MethodGet, "http://example.com/a", nil)
+	res1, err := ct.RoundTrip(req1)
+	if err != nil {
+		t.Fatalf("first RoundTrip() error = %v", err)
+	}
+	if body, _ := io.ReadAll(res1.Body); string(body) != "payload" {
+		t.Fatalf("first body = %q, want payload", body)
+	}
+
+	req2, _ := http.NewRequest(http.MethodGet, "http://example.com/a", nil)
+	res2, err := ct.RoundTrip(req2)
+	if err != nil {
+		t.Fatalf("second RoundTrip() error = %v", err)
+	}
+	if res2.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, want 200 (served from cache)", res2.StatusCode)
+	}
+	if body, _ := io.ReadAll(res2.Body); string(body) != "payload" {
+		t.Fatalf("second body = %q, want payload (from cache)", body)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestCacheTransportSkipsNonGET(t *testing.T) {
+	calls := 0
+	next := &stubRoundTripper{fn: func(*http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}}
+
+	ct := newCacheTransport(next)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/a", nil)
+	if _, err := ct.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	err := &APIError{
+		StatusCode: 400,
+		Messages:   []string{"bad request"},
+		Errors:     map[string]string{"summary": "is required"},
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "400") || !strings.Contains(msg, "bad request") || !strings.Contains(msg, "summary: is required") {
+		t.Fatalf("Error() = %q, missing expected parts", msg)
+	}
+}
+
+func TestAPIError_ErrorWithoutDetail(t *testing.T) {
+	err := &APIError{StatusCode: 500}
+	if got := err.Error(); got != "jira: unexpected status code: 500" {
+		t.Fatalf("Error() = %q", got)
+	}
+}
+
+func TestNewAPIErrorParsesEnvelope(t *testing.T) {
+	body := `{"errorMessages":["oops"],"errors":{"summary":"is required"}}`
+	res := &http.Response{StatusCode: 400, Body: io.NopCloser(strings.NewReader(body))}
+
+	apiErr, ok := newAPIError(res).(*APIError)
+	if !ok {
+		t.Fatalf("newAPIError() did not return *APIError")
+	}
+	if apiErr.StatusCode != 400 || len(apiErr.Messages) != 1 || apiErr.Messages[0] != "oops" || apiErr.Errors["summary"] != "is required" {
+		t.Fatalf("unexpected APIError: %+v", apiErr)
+	}
+}
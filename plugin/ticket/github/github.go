@@ -0,0 +1,181 @@
+// Package github implements the ticket.Backend interface on top of the
+// GitHub Issues REST API, so comply can drive GitHub as an alternative to
+// Jira.
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/ankitpokhrel/jira-cli/plugin/ticket"
+)
+
+const baseURL = "https://api.github.com"
+
+func init() {
+	ticket.Register("github", newBackend)
+	ticket.RegisterSetup("github", setup)
+}
+
+type backend struct {
+	token string
+	owner string
+	repo  string
+}
+
+func newBackend(cfg map[string]interface{}) (ticket.Backend, error) {
+	token, _ := cfg["token"].(string)
+	owner, _ := cfg["owner"].(string)
+	repo, _ := cfg["repo"].(string)
+
+	if token == "" || owner == "" || repo == "" {
+		return nil, fmt.Errorf("github: token, owner and repo are required")
+	}
+
+	return &backend{token: token, owner: owner, repo: repo}, nil
+}
+
+// setup interactively collects the credentials needed to talk to GitHub
+// and persists them under the github config key.
+func setup() error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("GitHub personal access token: ")
+	token, _ := reader.ReadString('\n')
+
+	fmt.Print("Repository owner: ")
+	owner, _ := reader.ReadString('\n')
+
+	fmt.Print("Repository name: ")
+	repo, _ := reader.ReadString('\n')
+
+	viper.Set("ticket_system", "github")
+	viper.Set("github.token", strings.TrimSpace(token))
+	viper.Set("github.owner", strings.TrimSpace(owner))
+	viper.Set("github.repo", strings.TrimSpace(repo))
+
+	return viper.WriteConfig()
+}
+
+type issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	URL    string `json:"html_url"`
+}
+
+func (b *backend) CreateTicket(ctx context.Context, t ticket.Ticket) (*ticket.Ticket, error) {
+	var res issue
+	if err := b.do(ctx, http.MethodPost, "/issues", map[string]string{"title": t.Title, "body": t.Body}, &res); err != nil {
+		return nil, err
+	}
+
+	return toTicket(res), nil
+}
+
+func (b *backend) GetTicket(ctx context.Context, id string) (*ticket.Ticket, error) {
+	var res issue
+	if err := b.do(ctx, http.MethodGet, "/issues/"+id, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return toTicket(res), nil
+}
+
+func (b *backend) ListTickets(ctx context.Context, query string) ([]ticket.Ticket, error) {
+	return b.Search(ctx, query)
+}
+
+func (b *backend) Search(ctx context.Context, query string) ([]ticket.Ticket, error) {
+	path := "/issues"
+	if query != "" {
+		path += "?" + query
+	}
+
+	var res []issue
+	if err := b.do(ctx, http.MethodGet, path, nil, &res); err != nil {
+		return nil, err
+	}
+
+	tickets := make([]ticket.Ticket, 0, len(res))
+	for _, i := range res {
+		tickets = append(tickets, *toTicket(i))
+	}
+
+	return tickets, nil
+}
+
+func (b *backend) TransitionTicket(ctx context.Context, id, status string) error {
+	state := "open"
+	if status == "closed" || status == "done" {
+		state = "closed"
+	}
+
+	return b.do(ctx, http.MethodPatch, "/issues/"+id, map[string]string{"state": state}, nil)
+}
+
+func (b *backend) AddComment(ctx context.Context, id string, c ticket.Comment) error {
+	return b.do(ctx, http.MethodPost, "/issues/"+id+"/comments", map[string]string{"body": c.Body}, nil)
+}
+
+func toTicket(i issue) *ticket.Ticket {
+	return &ticket.Ticket{
+		ID:     fmt.Sprintf("%d", i.Number),
+		Key:    fmt.Sprintf("#%d", i.Number),
+		Title:  i.Title,
+		Body:   i.Body,
+		Status: i.State,
+		URL:    i.URL,
+	}
+}
+
+func (b *backend) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s%s", baseURL, b.owner, b.repo, path)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("github: unexpected status code: %d", res.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,185 @@
+// Package gitlab implements the ticket.Backend interface on top of the
+// GitLab Issues REST API, so comply can drive GitLab as an alternative to
+// Jira.
+package gitlab
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/ankitpokhrel/jira-cli/plugin/ticket"
+)
+
+const defaultBaseURL = "https://gitlab.com/api/v4"
+
+func init() {
+	ticket.Register("gitlab", newBackend)
+	ticket.RegisterSetup("gitlab", setup)
+}
+
+type backend struct {
+	baseURL string
+	token   string
+	project string
+}
+
+func newBackend(cfg map[string]interface{}) (ticket.Backend, error) {
+	token, _ := cfg["token"].(string)
+	project, _ := cfg["project"].(string)
+	base, _ := cfg["server"].(string)
+
+	if token == "" || project == "" {
+		return nil, fmt.Errorf("gitlab: token and project are required")
+	}
+	if base == "" {
+		base = defaultBaseURL
+	}
+
+	return &backend{baseURL: strings.TrimSuffix(base, "/"), token: token, project: project}, nil
+}
+
+// setup interactively collects the credentials needed to talk to GitLab
+// and persists them under the gitlab config key.
+func setup() error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("GitLab server (blank for gitlab.com): ")
+	server, _ := reader.ReadString('\n')
+
+	fmt.Print("GitLab personal access token: ")
+	token, _ := reader.ReadString('\n')
+
+	fmt.Print("Project (namespace/name): ")
+	project, _ := reader.ReadString('\n')
+
+	viper.Set("ticket_system", "gitlab")
+	viper.Set("gitlab.server", strings.TrimSpace(server))
+	viper.Set("gitlab.token", strings.TrimSpace(token))
+	viper.Set("gitlab.project", strings.TrimSpace(project))
+
+	return viper.WriteConfig()
+}
+
+type issue struct {
+	IID   int    `json:"iid"`
+	Title string `json:"title"`
+	Desc  string `json:"description"`
+	State string `json:"state"`
+	URL   string `json:"web_url"`
+}
+
+func (b *backend) CreateTicket(ctx context.Context, t ticket.Ticket) (*ticket.Ticket, error) {
+	var res issue
+	body := map[string]string{"title": t.Title, "description": t.Body}
+	if err := b.do(ctx, http.MethodPost, "/issues", body, &res); err != nil {
+		return nil, err
+	}
+
+	return toTicket(res), nil
+}
+
+func (b *backend) GetTicket(ctx context.Context, id string) (*ticket.Ticket, error) {
+	var res issue
+	if err := b.do(ctx, http.MethodGet, "/issues/"+id, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return toTicket(res), nil
+}
+
+func (b *backend) ListTickets(ctx context.Context, query string) ([]ticket.Ticket, error) {
+	return b.Search(ctx, query)
+}
+
+func (b *backend) Search(ctx context.Context, query string) ([]ticket.Ticket, error) {
+	path := "/issues"
+	if query != "" {
+		path += "?search=" + url.QueryEscape(query)
+	}
+
+	var res []issue
+	if err := b.do(ctx, http.MethodGet, path, nil, &res); err != nil {
+		return nil, err
+	}
+
+	tickets := make([]ticket.Ticket, 0, len(res))
+	for _, i := range res {
+		tickets = append(tickets, *toTicket(i))
+	}
+
+	return tickets, nil
+}
+
+func (b *backend) TransitionTicket(ctx context.Context, id, status string) error {
+	stateEvent := "reopen"
+	if status == "closed" || status == "done" {
+		stateEvent = "close"
+	}
+
+	return b.do(ctx, http.MethodPut, "/issues/"+id, map[string]string{"state_event": stateEvent}, nil)
+}
+
+func (b *backend) AddComment(ctx context.Context, id string, c ticket.Comment) error {
+	return b.do(ctx, http.MethodPost, "/issues/"+id+"/notes", map[string]string{"body": c.Body}, nil)
+}
+
+func toTicket(i issue) *ticket.Ticket {
+	return &ticket.Ticket{
+		ID:     fmt.Sprintf("%d", i.IID),
+		Key:    fmt.Sprintf("#%d", i.IID),
+		Title:  i.Title,
+		Body:   i.Desc,
+		Status: i.State,
+		URL:    i.URL,
+	}
+}
+
+func (b *backend) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s%s", b.baseURL, url.PathEscape(b.project), path)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", b.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("gitlab: unexpected status code: %d", res.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,78 @@
+// Package ticket defines the pluggable ticketing backend abstraction used
+// by comply to drive an issue tracker end to end, independent of which
+// tracker is actually configured.
+package ticket
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ticket is a tracker-agnostic representation of an issue.
+type Ticket struct {
+	ID     string
+	Key    string
+	Title  string
+	Body   string
+	Status string
+	URL    string
+}
+
+// Comment is a note attached to a Ticket.
+type Comment struct {
+	Author string
+	Body   string
+}
+
+// Backend is implemented by ticket trackers that comply can drive end to
+// end: creating tickets, inspecting their state and moving them through a
+// workflow.
+type Backend interface {
+	CreateTicket(ctx context.Context, t Ticket) (*Ticket, error)
+	GetTicket(ctx context.Context, id string) (*Ticket, error)
+	ListTickets(ctx context.Context, query string) ([]Ticket, error)
+	TransitionTicket(ctx context.Context, id, status string) error
+	AddComment(ctx context.Context, id string, c Comment) error
+	Search(ctx context.Context, query string) ([]Ticket, error)
+}
+
+// Factory builds a Backend from the `ticket_system` config block in
+// comply.yml.
+type Factory func(cfg map[string]interface{}) (Backend, error)
+
+// SetupFunc walks the user through interactively configuring a backend,
+// invoked from `jira init` when the backend is selected via ticket_system.
+type SetupFunc func() error
+
+var (
+	backends = make(map[string]Factory)
+	setups   = make(map[string]SetupFunc)
+)
+
+// Register makes a backend factory available under name so it can be
+// selected via the ticket_system config key.
+func Register(name string, factory Factory) {
+	backends[name] = factory
+}
+
+// RegisterSetup makes an interactive setup routine available under name.
+func RegisterSetup(name string, setup SetupFunc) {
+	setups[name] = setup
+}
+
+// New instantiates the backend registered under name.
+func New(name string, cfg map[string]interface{}) (Backend, error) {
+	factory, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("ticket: unknown backend %q", name)
+	}
+
+	return factory(cfg)
+}
+
+// Setup returns the interactive setup routine registered under name, if
+// one exists.
+func Setup(name string) (SetupFunc, bool) {
+	setup, ok := setups[name]
+	return setup, ok
+}